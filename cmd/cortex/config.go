@@ -145,6 +145,10 @@ func makeConfigNode(ctx *cli.Context) (*node.Node, cortexConfig) {
 	//utils.SetShhConfig(ctx, stack, &cfg.Shh)
 	// utils.SetDashboardConfig(ctx, &cfg.Dashboard)
 	utils.SetTorrentFsConfig(ctx, &cfg.TorrentFs)
+	if err := utils.SetStorageTrackers(ctx, &cfg.TorrentFs, utils.StorageTrackerFlag); err != nil {
+		utils.Fatalf("%v", err)
+	}
+	log.Info("FsConfig", "trackers", cfg.TorrentFs.DefaultTrackers)
 
 	return stack, cfg
 }
@@ -184,16 +188,22 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 				"--cvm.max_seeding", strconv.Itoa(ctx.GlobalInt(utils.StorageMaxSeedingFlag.Name)),
 				"--cvm.max_active", strconv.Itoa(ctx.GlobalInt(utils.StorageMaxActiveFlag.Name)),
 				"--cvm.boostnodes", ctx.GlobalString(utils.StorageBoostNodesFlag.Name),
-				"--cvm.tracker", ctx.GlobalString(utils.StorageTrackerFlag.Name),
+				"--cvm.tracker", strings.Join(cfg.TorrentFs.DefaultTrackers, ","),
+				"--storage.upload_rate", strconv.Itoa(cfg.TorrentFs.UploadRate),
+				"--storage.download_rate", strconv.Itoa(cfg.TorrentFs.DownloadRate),
 			}
 			if ctx.GlobalBool(utils.StorageDisableDHTFlag.Name) {
 				args = append(args, "--storage.disable_dht")
 			}
 
-			if ctx.GlobalBool(utils.StorageDisableTCPFlag.Name) {
+			if cfg.TorrentFs.DisableTCP {
 				args = append(args, "--storage.disable_tcp")
 			}
 
+			if !cfg.TorrentFs.DisableUTP {
+				args = append(args, "--storage.enable_utp")
+			}
+
 			if ctx.GlobalBool(utils.StorageFullFlag.Name) {
 				args = append(args, "--storage.full")
 			}
@@ -95,7 +95,7 @@ var (
 	}
 	StorageTrackerFlag = cli.StringFlag{
 		Name:  "cvm.tracker",
-		Usage: "P2P storage tracker list",
+		Usage: "Comma separated P2P storage UDP trackers (://host:port)",
 		Value: strings.Join(torrentfs.DefaultConfig.DefaultTrackers, ","),
 	}
 	StorageDisableDHTFlag = cli.BoolFlag{
@@ -180,15 +180,16 @@ func cvmServer(ctx *cli.Context) error {
 
 	fsCfg := torrentfs.DefaultConfig
 	utils.SetTorrentFsConfig(ctx, &fsCfg)
-	trackers := ctx.GlobalString(StorageTrackerFlag.Name)
 	boostnodes := ctx.GlobalString(StorageBoostNodesFlag.Name)
-	fsCfg.DefaultTrackers = strings.Split(trackers, ",")
 	fsCfg.BoostNodes = strings.Split(boostnodes, ",")
 	fsCfg.MaxSeedingNum = ctx.GlobalInt(StorageMaxSeedingFlag.Name)
 	fsCfg.MaxActiveNum = ctx.GlobalInt(StorageMaxActiveFlag.Name)
 	fsCfg.DataDir = ctx.GlobalString(utils.StorageDirFlag.Name)
 	fsCfg.DisableDHT = ctx.GlobalBool(utils.StorageDisableDHTFlag.Name)
-	//fsCfg.DisableTCP = ctx.GlobalBool(utils.StorageDisableTCPFlag.Name)
+	if err := utils.SetStorageTrackers(ctx, &fsCfg, StorageTrackerFlag); err != nil {
+		return err
+	}
+	log.Info("Cvm Server", "trackers", fsCfg.DefaultTrackers)
 	fsCfg.FullSeed = ctx.GlobalBool(utils.StorageFullFlag.Name)
 	fsCfg.Boost = ctx.GlobalBool(utils.StorageBoostFlag.Name)
 	fsCfg.IpcPath = filepath.Join(ctx.GlobalString(CVMCortexDir.Name), "cortex.ipc")
@@ -147,8 +147,13 @@ var (
 		utils.StorageMaxActiveFlag,
 		//utils.StorageBoostNodesFlag,
 		utils.StorageTrackerFlag,
+		utils.StorageExtraTrackerFlag,
+		utils.StorageNoDefaultTrackerFlag,
 		utils.StorageDisableDHTFlag,
 		utils.StorageDisableTCPFlag,
+		utils.StorageEnableUTPFlag,
+		utils.StorageUploadRateFlag,
+		utils.StorageDownloadRateFlag,
 		utils.StorageFullFlag,
 		//utils.StorageBoostFlag,
 	}
@@ -211,8 +211,13 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.StorageMaxActiveFlag,
 			//utils.StorageBoostNodesFlag,
 			utils.StorageTrackerFlag,
+			utils.StorageExtraTrackerFlag,
+			utils.StorageNoDefaultTrackerFlag,
 			utils.StorageDisableDHTFlag,
 			utils.StorageDisableTCPFlag,
+			utils.StorageEnableUTPFlag,
+			utils.StorageUploadRateFlag,
+			utils.StorageDownloadRateFlag,
 			utils.StorageFullFlag,
 			//utils.StorageBoostFlag,
 		},
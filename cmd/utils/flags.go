@@ -231,9 +231,17 @@ var (
 	}
 	StorageTrackerFlag = cli.StringFlag{
 		Name:  "storage.tracker",
-		Usage: "P2P storage tracker list",
+		Usage: "Comma separated P2P storage UDP trackers (://host:port)",
 		Value: strings.Join(torrentfs.DefaultConfig.DefaultTrackers, ","),
 	}
+	StorageExtraTrackerFlag = cli.StringFlag{
+		Name:  "storage.extra_tracker",
+		Usage: "Comma separated UDP trackers (://host:port) appended to the P2P storage tracker list",
+	}
+	StorageNoDefaultTrackerFlag = cli.BoolFlag{
+		Name:  "storage.no_default_tracker",
+		Usage: "Use only the trackers given by --storage.extra_tracker",
+	}
 	StorageDisableDHTFlag = cli.BoolFlag{
 		Name:  "storage.disable_dht",
 		Usage: "disable DHT network in FS",
@@ -246,6 +254,20 @@ var (
 		Name:  "storage.disable_tcp",
 		Usage: "disable TCP network in FS (EXPERIMENTAL)",
 	}
+	StorageUploadRateFlag = cli.IntFlag{
+		Name:  "storage.upload_rate",
+		Usage: "P2P storage upload rate limit in bytes per second (<= 0 means unlimited)",
+		Value: torrentfs.DefaultConfig.UploadRate,
+	}
+	StorageDownloadRateFlag = cli.IntFlag{
+		Name:  "storage.download_rate",
+		Usage: "P2P storage download rate limit in bytes per second (<= 0 means unlimited)",
+		Value: torrentfs.DefaultConfig.DownloadRate,
+	}
+	StorageEnableUTPFlag = cli.BoolFlag{
+		Name:  "storage.enable_utp",
+		Usage: "enable UTP network in FS",
+	}
 	StorageFullFlag = cli.BoolFlag{
 		Name:  "storage.full",
 		Usage: "download full file",
@@ -1471,7 +1493,7 @@ func SetTorrentFsConfig(ctx *cli.Context, cfg *torrentfs.Config) {
 	IPCDisabled := ctx.GlobalBool(IPCDisabledFlag.Name)
 	if runtime.GOOS == "windows" || IPCDisabled {
 		cfg.IpcPath = ""
-		cfg.RpcURI = "http://" + ctx.GlobalString(RPCListenAddrFlag.Name) + ":" + string(ctx.GlobalInt(RPCPortFlag.Name))
+		cfg.RpcURI = "http://" + ctx.GlobalString(RPCListenAddrFlag.Name) + ":" + strconv.Itoa(ctx.GlobalInt(RPCPortFlag.Name))
 	} else {
 		path := MakeDataDir(ctx)
 		IPCPath := ctx.GlobalString(IPCPathFlag.Name)
@@ -1479,9 +1501,7 @@ func SetTorrentFsConfig(ctx *cli.Context, cfg *torrentfs.Config) {
 		log.Info("path", "path", path, "ipc", IPCPath)
 		log.Info("FsConfig", "IPCPath", cfg.IpcPath)
 	}
-	trackers := ctx.GlobalString(StorageTrackerFlag.Name)
 	boostnodes := ctx.GlobalString(StorageBoostNodesFlag.Name)
-	cfg.DefaultTrackers = strings.Split(trackers, ",")
 	cfg.BoostNodes = strings.Split(boostnodes, ",")
 	cfg.MaxSeedingNum = ctx.GlobalInt(StorageMaxSeedingFlag.Name)
 	log.Debug("FsConfig", "MaxSeedingNum", ctx.GlobalInt(StorageMaxSeedingFlag.Name),
@@ -1489,12 +1509,138 @@ func SetTorrentFsConfig(ctx *cli.Context, cfg *torrentfs.Config) {
 	cfg.MaxActiveNum = ctx.GlobalInt(StorageMaxActiveFlag.Name)
 	cfg.SyncMode = ctx.GlobalString(SyncModeFlag.Name)
 	cfg.DisableDHT = ctx.GlobalBool(StorageDisableDHTFlag.Name)
-	//cfg.DisableTCP = ctx.GlobalBool(StorageDisableTCPFlag.Name)
+	if err := setStorageTransports(ctx, cfg); err != nil {
+		Fatalf("%v", err)
+	}
 	cfg.FullSeed = ctx.GlobalBool(StorageFullFlag.Name)
 	cfg.Boost = ctx.GlobalBool(StorageBoostFlag.Name)
+	setStorageRates(ctx, cfg)
 	cfg.DataDir = MakeStorageDir(ctx)
 }
 
+// setStorageRates applies the upload/download rate limit flags to the config.
+// The flags only override the config when given explicitly. A rate <= 0 means
+// unlimited.
+func setStorageRates(ctx *cli.Context, cfg *torrentfs.Config) {
+	if ctx.GlobalIsSet(StorageUploadRateFlag.Name) {
+		cfg.UploadRate = ctx.GlobalInt(StorageUploadRateFlag.Name)
+	}
+	if ctx.GlobalIsSet(StorageDownloadRateFlag.Name) {
+		cfg.DownloadRate = ctx.GlobalInt(StorageDownloadRateFlag.Name)
+	}
+}
+
+// setStorageTransports applies the TCP/UTP transport flags to the config. The
+// flags only override the config when given explicitly, and at least one
+// transport has to remain enabled.
+func setStorageTransports(ctx *cli.Context, cfg *torrentfs.Config) error {
+	if ctx.GlobalIsSet(StorageDisableTCPFlag.Name) {
+		cfg.DisableTCP = ctx.GlobalBool(StorageDisableTCPFlag.Name)
+	}
+	if ctx.GlobalIsSet(StorageEnableUTPFlag.Name) {
+		cfg.DisableUTP = !ctx.GlobalBool(StorageEnableUTPFlag.Name)
+	}
+	if cfg.DisableTCP && cfg.DisableUTP {
+		return fmt.Errorf("P2P storage has both TCP and UTP disabled (DisableTCP and DisableUTP in config), drop --%s or set --%s", StorageDisableTCPFlag.Name, StorageEnableUTPFlag.Name)
+	}
+	return nil
+}
+
+// SetStorageTrackers sets the tracker list of the storage layer: the comma
+// separated list of the base flag, or the DefaultTrackers of the config if the
+// flag is not given, followed by the --storage.extra_tracker entries.
+// --storage.no_default_tracker drops the config list but not an explicit base
+// flag. Without any tracker torrents can only be found through the DHT, so an
+// empty list is an error when the DHT is disabled too. It must run after
+// cfg.DisableDHT has been set.
+func SetStorageTrackers(ctx *cli.Context, cfg *torrentfs.Config, base cli.StringFlag) error {
+	var (
+		trackers []string
+		err      error
+	)
+	switch {
+	case ctx.GlobalIsSet(base.Name):
+		trackers, err = normaliseTrackers(strings.Split(ctx.GlobalString(base.Name), ","), "--"+base.Name)
+	case !ctx.GlobalBool(StorageNoDefaultTrackerFlag.Name):
+		trackers, err = normaliseTrackers(cfg.DefaultTrackers, "DefaultTrackers in config")
+	}
+	if err != nil {
+		return err
+	}
+	extra, err := normaliseTrackers(strings.Split(ctx.GlobalString(StorageExtraTrackerFlag.Name), ","), "--"+StorageExtraTrackerFlag.Name)
+	if err != nil {
+		return err
+	}
+	trackers = mergeTrackers(trackers, extra)
+	if len(trackers) == 0 {
+		if cfg.DisableDHT {
+			return fmt.Errorf("P2P storage has no trackers and DHT disabled, set --%s or --%s, or drop --%s", base.Name, StorageExtraTrackerFlag.Name, StorageDisableDHTFlag.Name)
+		}
+		log.Warn("P2P storage has no trackers, relying on DHT only")
+	}
+	cfg.DefaultTrackers = trackers
+	return nil
+}
+
+// normaliseTrackers checks that every tracker from source is in the
+// "://host:port" form torrentfs expects. torrentfs prepends "udp" to each entry
+// itself, so an explicit udp:// scheme is stripped and any other scheme is
+// rejected. Empty entries are dropped.
+func normaliseTrackers(trackers []string, source string) ([]string, error) {
+	var list []string
+	for _, tracker := range trackers {
+		tracker = strings.TrimSpace(tracker)
+		if tracker == "" {
+			continue
+		}
+		i := strings.Index(tracker, "://")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid tracker %q in %s, expected ://host:port", tracker, source)
+		}
+		if scheme := tracker[:i]; scheme != "" && !strings.EqualFold(scheme, "udp") {
+			return nil, fmt.Errorf("unsupported tracker %q in %s, only UDP trackers (://host:port) are supported", tracker, source)
+		}
+		list = append(list, tracker[i:])
+	}
+	return list, nil
+}
+
+// mergeTrackers concatenates the tracker lists, dropping empty entries and
+// duplicates as defined by trackerKey. The first spelling seen is kept.
+func mergeTrackers(lists ...[]string) []string {
+	var (
+		merged []string
+		seen   = make(map[string]bool)
+	)
+	for _, list := range lists {
+		for _, tracker := range list {
+			tracker = strings.TrimSpace(tracker)
+			key := trackerKey(tracker)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, tracker)
+		}
+	}
+	return merged
+}
+
+// trackerKey normalises a "://host:port[/path]" tracker as returned by
+// normaliseTrackers for deduplication. Trailing slashes are ignored and the
+// host is compared case-insensitively, the path is kept as is.
+func trackerKey(tracker string) string {
+	rest := strings.TrimPrefix(strings.TrimRight(tracker, "/"), "://")
+	if rest == "" {
+		return ""
+	}
+	host, path := rest, ""
+	if i := strings.Index(rest, "/"); i >= 0 {
+		host, path = rest[:i], rest[i:]
+	}
+	return "://" + strings.ToLower(host) + path
+}
+
 // RegisterCortexService adds an Cortex client to the stack.
 func RegisterCortexService(stack *node.Node, cfg *ctxc.Config) {
 	var err error
@@ -0,0 +1,214 @@
+// Copyright 2020 The CortexTheseus Authors
+// This file is part of CortexFoundation.
+//
+// CortexFoundation is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// CortexFoundation is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with CortexFoundation. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+
+	"github.com/CortexFoundation/torrentfs"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// newTestContext returns a cli context with the given flags parsed from args.
+func newTestContext(t *testing.T, flags []cli.Flag, args ...string) *cli.Context {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	for _, f := range flags {
+		f.Apply(set)
+	}
+	if err := set.Parse(args); err != nil {
+		t.Fatalf("failed to parse %v: %v", args, err)
+	}
+	return cli.NewContext(nil, set, nil)
+}
+
+func TestSetStorageRates(t *testing.T) {
+	flags := []cli.Flag{StorageUploadRateFlag, StorageDownloadRateFlag}
+	tests := []struct {
+		args             []string
+		up, down         int // values from the config file
+		wantUp, wantDown int
+	}{
+		// Without flags the config file values are kept.
+		{args: nil, up: 1024, down: 2048, wantUp: 1024, wantDown: 2048},
+		{args: nil, up: -1, down: -1, wantUp: -1, wantDown: -1},
+		// Flags override the config file.
+		{args: []string{"--storage.upload_rate", "4096"}, up: 1024, down: 2048, wantUp: 4096, wantDown: 2048},
+		{args: []string{"--storage.download_rate", "8192"}, up: 1024, down: 2048, wantUp: 1024, wantDown: 8192},
+		// Both 0 and -1 mean unlimited.
+		{args: []string{"--storage.upload_rate", "0", "--storage.download_rate", "-1"}, up: 1024, down: 2048, wantUp: 0, wantDown: -1},
+	}
+	for i, test := range tests {
+		cfg := torrentfs.DefaultConfig
+		cfg.UploadRate, cfg.DownloadRate = test.up, test.down
+		setStorageRates(newTestContext(t, flags, test.args...), &cfg)
+		if cfg.UploadRate != test.wantUp || cfg.DownloadRate != test.wantDown {
+			t.Errorf("test %d: got up=%d down=%d, want up=%d down=%d", i, cfg.UploadRate, cfg.DownloadRate, test.wantUp, test.wantDown)
+		}
+	}
+}
+
+func TestSetStorageTransports(t *testing.T) {
+	flags := []cli.Flag{StorageDisableTCPFlag, StorageEnableUTPFlag}
+	tests := []struct {
+		args       []string
+		disableUTP bool // value from the config file
+		wantTCP    bool
+		wantUTP    bool
+		wantErr    bool
+	}{
+		{args: nil, disableUTP: true, wantTCP: true, wantUTP: false},
+		{args: []string{"--storage.enable_utp"}, disableUTP: true, wantTCP: true, wantUTP: true},
+		{args: []string{"--storage.disable_tcp", "--storage.enable_utp"}, disableUTP: true, wantTCP: false, wantUTP: true},
+		{args: []string{"--storage.disable_tcp"}, disableUTP: true, wantErr: true},
+		// UTP enabled in the config file survives when no UTP flag is given.
+		{args: []string{"--storage.disable_tcp"}, disableUTP: false, wantTCP: false, wantUTP: true},
+		{args: []string{"--storage.enable_utp=false"}, disableUTP: false, wantTCP: true, wantUTP: false},
+	}
+	for i, test := range tests {
+		cfg := torrentfs.DefaultConfig
+		cfg.DisableUTP = test.disableUTP
+		err := setStorageTransports(newTestContext(t, flags, test.args...), &cfg)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("test %d: expected error, got none", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+			continue
+		}
+		if tcp, utp := !cfg.DisableTCP, !cfg.DisableUTP; tcp != test.wantTCP || utp != test.wantUTP {
+			t.Errorf("test %d: got tcp=%v utp=%v, want tcp=%v utp=%v", i, tcp, utp, test.wantTCP, test.wantUTP)
+		}
+	}
+}
+
+func TestMergeTrackers(t *testing.T) {
+	tests := []struct {
+		lists [][]string
+		want  []string
+	}{
+		{
+			lists: [][]string{{""}, {""}},
+			want:  nil,
+		},
+		{
+			lists: [][]string{{"://tracker.cortexlabs.ai:5008"}, {""}},
+			want:  []string{"://tracker.cortexlabs.ai:5008"},
+		},
+		{
+			lists: [][]string{
+				{"://tracker.cortexlabs.ai:5008"},
+				{"://Tracker.CortexLabs.ai:5008/", " ://tracker.example.org:6969 "},
+			},
+			want: []string{"://tracker.cortexlabs.ai:5008", "://tracker.example.org:6969"},
+		},
+		{
+			lists: [][]string{
+				{"://a.example.org:1", "://b.example.org:2", "://a.example.org:1/"},
+				{"://c.example.org:3", "://B.example.org:2"},
+			},
+			want: []string{"://a.example.org:1", "://b.example.org:2", "://c.example.org:3"},
+		},
+		{
+			lists: [][]string{
+				{"://tracker.example.org:80/announce/AbC123"},
+				{"://Tracker.example.org:80/announce/abc123", "://tracker.example.org:80/announce/AbC123/"},
+			},
+			want: []string{"://tracker.example.org:80/announce/AbC123", "://Tracker.example.org:80/announce/abc123"},
+		},
+	}
+	for i, test := range tests {
+		if got := mergeTrackers(test.lists...); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("test %d: got %q, want %q", i, got, test.want)
+		}
+	}
+}
+
+func TestSetStorageTrackers(t *testing.T) {
+	flags := []cli.Flag{StorageTrackerFlag, StorageExtraTrackerFlag, StorageNoDefaultTrackerFlag}
+	defaults := torrentfs.DefaultConfig.DefaultTrackers
+	tests := []struct {
+		args       []string
+		config     []string // DefaultTrackers from the config file, nil for the defaults
+		disableDHT bool
+		want       []string
+		wantErr    bool
+	}{
+		{args: nil, want: defaults},
+		{
+			args: []string{"--storage.extra_tracker", "://tracker.example.org:6969"},
+			want: append(append([]string{}, defaults...), "://tracker.example.org:6969"),
+		},
+		{
+			args: []string{"--storage.no_default_tracker", "--storage.extra_tracker", "://tracker.example.org:6969"},
+			want: []string{"://tracker.example.org:6969"},
+		},
+		// An explicit base list is kept, only the built-in one is dropped.
+		{
+			args: []string{"--storage.no_default_tracker", "--storage.tracker", "://a.example.org:1"},
+			want: []string{"://a.example.org:1"},
+		},
+		{args: []string{"--storage.no_default_tracker"}, want: nil},
+		{args: []string{"--storage.no_default_tracker"}, disableDHT: true, wantErr: true},
+		{args: []string{"--storage.tracker", ""}, disableDHT: true, wantErr: true},
+		// An explicit udp:// scheme is stripped, torrentfs adds it back.
+		{
+			args: []string{"--storage.tracker", "udp://a.example.org:1", "--storage.extra_tracker", "UDP://b.example.org:2,://A.example.org:1"},
+			want: []string{"://a.example.org:1", "://b.example.org:2"},
+		},
+		// Other schemes and entries without "://" are rejected.
+		{args: []string{"--storage.tracker", "http://a.example.org:80/announce"}, wantErr: true},
+		{args: []string{"--storage.extra_tracker", "https://a.example.org:443/announce"}, wantErr: true},
+		{args: []string{"--storage.extra_tracker", "ws://a.example.org:80"}, wantErr: true},
+		{args: []string{"--storage.extra_tracker", "a.example.org:1"}, wantErr: true},
+		// Without the base flag the list comes from the config file.
+		{
+			config: []string{"udp://cfg.example.org:1"},
+			args:   []string{"--storage.extra_tracker", "://b.example.org:2"},
+			want:   []string{"://cfg.example.org:1", "://b.example.org:2"},
+		},
+		{config: []string{"://cfg.example.org:1"}, args: []string{"--storage.tracker", "://a.example.org:1"}, want: []string{"://a.example.org:1"}},
+		{config: []string{"://cfg.example.org:1"}, args: []string{"--storage.no_default_tracker"}, want: nil},
+		{config: []string{"http://cfg.example.org:80/announce"}, wantErr: true},
+	}
+	for i, test := range tests {
+		cfg := torrentfs.DefaultConfig
+		if test.config != nil {
+			cfg.DefaultTrackers = test.config
+		}
+		cfg.DisableDHT = test.disableDHT
+		err := SetStorageTrackers(newTestContext(t, flags, test.args...), &cfg, StorageTrackerFlag)
+		got := cfg.DefaultTrackers
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("test %d: expected error, got %q", i, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("test %d: got %q, want %q", i, got, test.want)
+		}
+	}
+}